@@ -0,0 +1,144 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CIDR defines a CIDR value, such as 192.168.0.0/24 or 192.168.0.1/32.
+type CIDR string
+
+// ExternalServiceIPsSpec defines the allowed IPs, in CIDR notation, a Tenant is able to assign
+// to Service resources of type ExternalIPs or LoadBalancer.
+type ExternalServiceIPsSpec struct {
+	// Defines the allowed IPs for spec.externalIPs of any Service.
+	// A Service with an external IP not in the range defined here is forbidden.
+	// spec.loadBalancerIP is not checked against this list.
+	Allowed []CIDR `json:"allowed"`
+	// Allowed IP pool the Tenant can select spec.loadBalancerIP from, mirroring the way
+	// Allowed already gates spec.externalIPs.
+	// +optional
+	AllowedLoadBalancerIPs []CIDR `json:"allowedLoadBalancerIPs,omitempty"`
+	// When set, every LoadBalancer Service created by the Tenant must declare
+	// spec.loadBalancerSourceRanges as a subset of the CIDRs listed here.
+	// +optional
+	RequiredLoadBalancerSourceRanges []CIDR `json:"requiredLoadBalancerSourceRanges,omitempty"`
+}
+
+// DeprecatedEnableNodePortsAnnotation is the legacy, annotation-based way of disabling NodePort
+// Services for a Tenant. Superseded by ServiceOptions.AllowedTypes, it is still honoured as a
+// fallback when spec.services is nil.
+//
+// Deprecated: use TenantSpec.Services.AllowedTypes instead.
+const DeprecatedEnableNodePortsAnnotation = "capsule.clastix.io/enable-node-ports"
+
+// ServiceOptions defines the Service policies for a Tenant.
+type ServiceOptions struct {
+	// Defines the allowed Service types for the Tenant. An empty or nil list means
+	// all Service types are allowed.
+	// +optional
+	AllowedTypes []corev1.ServiceType `json:"allowedTypes,omitempty"`
+}
+
+// ServiceOptions returns the effective Service policy for the Tenant, falling back to the
+// DeprecatedEnableNodePortsAnnotation annotation when spec.services is nil.
+func (t *Tenant) ServiceOptions() *ServiceOptions {
+	if t.Spec.Services != nil {
+		return t.Spec.Services
+	}
+
+	if t.GetAnnotations()[DeprecatedEnableNodePortsAnnotation] == "false" {
+		return &ServiceOptions{
+			AllowedTypes: []corev1.ServiceType{
+				corev1.ServiceTypeClusterIP,
+				corev1.ServiceTypeLoadBalancer,
+				corev1.ServiceTypeExternalName,
+			},
+		}
+	}
+
+	return nil
+}
+
+// ServiceDefaultsAnnotation lets a Tenant opt out of the externalIPs and loadBalancer checks
+// performed by the validating webhook in favour of the mutating companion webhook, or disable
+// both. The Service type and externalName checks are not affected by it.
+const ServiceDefaultsAnnotation = "capsule.clastix.io/service-defaults"
+
+const (
+	// ServiceDefaultsEnforce rejects non-compliant Services. This is the default when the
+	// annotation is not set.
+	ServiceDefaultsEnforce = "enforce"
+	// ServiceDefaultsMutate skips the rejection and instead defaults an empty
+	// loadBalancerSourceRanges and strips disallowed externalIPs. A forbidden loadBalancerIP or
+	// out-of-range loadBalancerSourceRanges are neither rejected nor corrected.
+	ServiceDefaultsMutate = "mutate"
+	// ServiceDefaultsOff disables both the rejection and the correction behaviour.
+	ServiceDefaultsOff = "off"
+)
+
+// ServiceDefaultsMode returns the Tenant's opt-in for the externalIPs/loadBalancerSourceRanges
+// defaulting behaviour, defaulting to ServiceDefaultsEnforce for unset or unrecognised values.
+func (t *Tenant) ServiceDefaultsMode() string {
+	switch mode := t.GetAnnotations()[ServiceDefaultsAnnotation]; mode {
+	case ServiceDefaultsMutate, ServiceDefaultsOff:
+		return mode
+	default:
+		return ServiceDefaultsEnforce
+	}
+}
+
+// ExternalNameOptions defines the ExternalName Service policies for a Tenant.
+type ExternalNameOptions struct {
+	// Allowed hostnames spec.externalName is allowed to resolve to. Entries are matched
+	// exactly, or as a single-label wildcard prefix such as *.example.com.
+	AllowedHostnames []string `json:"allowedHostnames"`
+}
+
+// TenantSpec defines the desired state of Tenant.
+//
+// NOTE: this type only carries the fields currently exercised by the Service admission webhook;
+// the remaining Tenant spec surface (namespace quotas, network policies, RBAC, and so on) is
+// intentionally out of scope here.
+type TenantSpec struct {
+	// ExternalServiceIPs defines the IP pool a Tenant can use for Service resources of type
+	// ExternalIPs or LoadBalancer.
+	// +optional
+	ExternalServiceIPs *ExternalServiceIPsSpec `json:"externalServiceIPs,omitempty"`
+	// Services defines the Service policies enforced for the Tenant, such as the allowed
+	// Service types.
+	// +optional
+	Services *ServiceOptions `json:"services,omitempty"`
+	// ExternalName defines the policies for Service resources of type ExternalName.
+	// +optional
+	ExternalName *ExternalNameOptions `json:"externalName,omitempty"`
+}
+
+// TenantStatus defines the observed state of Tenant.
+type TenantStatus struct {
+	// Namespaces assigned to the Tenant.
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// Tenant is the Schema for the tenants API.
+type Tenant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantSpec   `json:"spec,omitempty"`
+	Status TenantStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TenantList contains a list of Tenant.
+type TenantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Tenant `json:"items"`
+}
@@ -0,0 +1,50 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/clastix/capsule/api/v1alpha1"
+)
+
+// Reconciler reconciles a Tenant object.
+type Reconciler struct {
+	client.Client
+}
+
+// Reconcile migrates deprecated Tenant spec fields, such as the enable-node-ports annotation,
+// onto their first-class replacements.
+//
+// NOTE: this reconciler only carries the migration logic exercised by this backlog; the
+// remaining Tenant reconciliation (namespace provisioning, RBAC, quotas, and so on) lives
+// elsewhere and is out of scope here.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	tnt := &v1alpha1.Tenant{}
+	if err := r.Get(ctx, req.NamespacedName, tnt); err != nil {
+		return reconcile.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if defaultServiceOptions(tnt) {
+		if err := r.Update(ctx, tnt); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := SetupIndexers(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1alpha1.Tenant{}).
+		Complete(r)
+}
@@ -0,0 +1,26 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/clastix/capsule/api/v1alpha1"
+)
+
+// namespacesIndexKey is the field index used by the Service admission webhooks to look up the
+// Tenant owning a given namespace.
+const namespacesIndexKey = ".status.namespaces"
+
+// SetupIndexers registers the field indexers required by the Tenant controller and the
+// webhooks that depend on them, such as the Service admission webhooks under pkg/webhook/services.
+func SetupIndexers(ctx context.Context, fieldIndexer client.FieldIndexer) error {
+	return fieldIndexer.IndexField(ctx, &v1alpha1.Tenant{}, namespacesIndexKey, func(object client.Object) []string {
+		tnt := object.(*v1alpha1.Tenant)
+
+		return tnt.Status.Namespaces
+	})
+}
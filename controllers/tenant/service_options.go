@@ -0,0 +1,26 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package tenant
+
+import (
+	"github.com/clastix/capsule/api/v1alpha1"
+)
+
+// defaultServiceOptions migrates the deprecated enable-node-ports annotation onto
+// spec.services.allowedTypes the first time a Tenant without spec.services is reconciled,
+// so the annotation can eventually be dropped. It reports whether the Tenant was mutated.
+func defaultServiceOptions(tnt *v1alpha1.Tenant) bool {
+	if tnt.Spec.Services != nil {
+		return false
+	}
+
+	options := tnt.ServiceOptions()
+	if options == nil {
+		return false
+	}
+
+	tnt.Spec.Services = options
+
+	return true
+}
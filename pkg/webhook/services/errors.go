@@ -0,0 +1,84 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/clastix/capsule/api/v1alpha1"
+)
+
+type serviceTypeForbiddenError struct {
+	allowed []corev1.ServiceType
+}
+
+func NewServiceTypeForbiddenError(allowed []corev1.ServiceType) error {
+	return &serviceTypeForbiddenError{allowed: allowed}
+}
+
+func (e serviceTypeForbiddenError) Error() string {
+	return fmt.Sprintf("The Service type is forbidden for the current Tenant, allowed types are %v", e.allowed)
+}
+
+type externalServiceIPForbiddenError struct {
+	allowed []v1alpha1.CIDR
+}
+
+func NewExternalServiceIPForbidden(allowed []v1alpha1.CIDR) error {
+	return &externalServiceIPForbiddenError{allowed: allowed}
+}
+
+func (e externalServiceIPForbiddenError) Error() string {
+	return fmt.Sprintf("The Service external IP is forbidden for the current Tenant, allowed values are %v", e.allowed)
+}
+
+type loadBalancerIPForbiddenError struct {
+	allowed []v1alpha1.CIDR
+}
+
+func NewLoadBalancerIPForbiddenError(allowed []v1alpha1.CIDR) error {
+	return &loadBalancerIPForbiddenError{allowed: allowed}
+}
+
+func (e loadBalancerIPForbiddenError) Error() string {
+	return fmt.Sprintf("The Service loadBalancerIP is forbidden for the current Tenant, allowed values are %v", e.allowed)
+}
+
+type loadBalancerSourceRangeForbiddenError struct {
+	allowed []v1alpha1.CIDR
+}
+
+func NewLoadBalancerSourceRangeForbiddenError(allowed []v1alpha1.CIDR) error {
+	return &loadBalancerSourceRangeForbiddenError{allowed: allowed}
+}
+
+func (e loadBalancerSourceRangeForbiddenError) Error() string {
+	return fmt.Sprintf("The Service loadBalancerSourceRanges must be a subset of %v for the current Tenant", e.allowed)
+}
+
+type externalNameForbiddenError struct {
+	allowed []string
+}
+
+func NewExternalNameForbidden(allowed []string) error {
+	return &externalNameForbiddenError{allowed: allowed}
+}
+
+func (e externalNameForbiddenError) Error() string {
+	return fmt.Sprintf("The Service externalName is forbidden for the current Tenant, allowed hostnames are %v", e.allowed)
+}
+
+type missingLoadBalancerSourceRangesError struct {
+	required []v1alpha1.CIDR
+}
+
+func NewMissingLoadBalancerSourceRangesError(required []v1alpha1.CIDR) error {
+	return &missingLoadBalancerSourceRangesError{required: required}
+}
+
+func (e missingLoadBalancerSourceRangesError) Error() string {
+	return fmt.Sprintf("The Service must declare loadBalancerSourceRanges as a subset of %v for the current Tenant", e.required)
+}
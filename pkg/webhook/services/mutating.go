@@ -0,0 +1,131 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/clastix/capsule/api/v1alpha1"
+	capsulewebhook "github.com/clastix/capsule/pkg/webhook"
+)
+
+// +kubebuilder:webhook:path=/mutating-service-defaults,mutating=true,sideEffects=None,admissionReviewVersions=v1,failurePolicy=ignore,groups="",resources=services,verbs=create;update,versions=v1,name=mutating-service-defaults.capsule.clastix.io
+
+type mutatingWebhook struct {
+	handler capsulewebhook.Handler
+}
+
+func MutatingWebhook(handler capsulewebhook.Handler) capsulewebhook.Webhook {
+	return &mutatingWebhook{handler: handler}
+}
+
+func (w *mutatingWebhook) GetHandler() capsulewebhook.Handler {
+	return w.handler
+}
+
+func (w *mutatingWebhook) GetName() string {
+	return "ServiceDefaults"
+}
+
+func (w *mutatingWebhook) GetPath() string {
+	return "/mutating-service-defaults"
+}
+
+type mutatingHandler struct{}
+
+func MutatingHandler() capsulewebhook.Handler {
+	return &mutatingHandler{}
+}
+
+// handleServiceDefaults defaults spec.loadBalancerSourceRanges and strips disallowed
+// spec.externalIPs for Services belonging to a Tenant that opted in via the
+// v1alpha1.ServiceDefaultsAnnotation annotation. Unlike the validating webhook, it never rejects
+// a Service on policy grounds; only a decoding or Tenant lookup failure returns an error.
+// Tenants without ExternalServiceIPs configured, or not in ServiceDefaultsMutate mode, are left
+// untouched.
+func (r *mutatingHandler) handleServiceDefaults(ctx context.Context, clt client.Client, decoder *admission.Decoder, req admission.Request) admission.Response {
+	svc := &corev1.Service{}
+	if err := decoder.Decode(req, svc); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	tntList := &v1alpha1.TenantList{}
+	if err := clt.List(ctx, tntList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(".status.namespaces", svc.GetNamespace()),
+	}); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if len(tntList.Items) == 0 {
+		return admission.Allowed("")
+	}
+	tnt := tntList.Items[0]
+
+	if tnt.Spec.ExternalServiceIPs == nil || tnt.ServiceDefaultsMode() != v1alpha1.ServiceDefaultsMutate {
+		return admission.Allowed("")
+	}
+
+	mutated := applyServiceDefaults(&tnt, svc)
+
+	marshaled, err := json.Marshal(mutated)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// applyServiceDefaults returns a copy of svc with spec.loadBalancerSourceRanges defaulted, and
+// spec.externalIPs stripped of any IP outside tnt.Spec.ExternalServiceIPs.Allowed. The caller
+// must have already verified tnt.Spec.ExternalServiceIPs is non-nil.
+func applyServiceDefaults(tnt *v1alpha1.Tenant, svc *corev1.Service) *corev1.Service {
+	mutated := svc.DeepCopy()
+
+	if mutated.Spec.Type == corev1.ServiceTypeLoadBalancer && len(mutated.Spec.LoadBalancerSourceRanges) == 0 {
+		defaults := tnt.Spec.ExternalServiceIPs.Allowed
+		if len(tnt.Spec.ExternalServiceIPs.AllowedLoadBalancerIPs) > 0 {
+			defaults = tnt.Spec.ExternalServiceIPs.AllowedLoadBalancerIPs
+		}
+
+		for _, cidr := range defaults {
+			mutated.Spec.LoadBalancerSourceRanges = append(mutated.Spec.LoadBalancerSourceRanges, string(cidr))
+		}
+	}
+
+	externalIPs := mutated.Spec.ExternalIPs[:0]
+	for _, externalIP := range mutated.Spec.ExternalIPs {
+		if cidrListContainsIP(tnt.Spec.ExternalServiceIPs.Allowed, net.ParseIP(externalIP)) {
+			externalIPs = append(externalIPs, externalIP)
+		}
+	}
+	mutated.Spec.ExternalIPs = externalIPs
+
+	return mutated
+}
+
+func (r *mutatingHandler) OnCreate(client client.Client, decoder *admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) admission.Response {
+		return r.handleServiceDefaults(ctx, client, decoder, req)
+	}
+}
+
+func (r *mutatingHandler) OnUpdate(client client.Client, decoder *admission.Decoder, _ record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) admission.Response {
+		return r.handleServiceDefaults(ctx, client, decoder, req)
+	}
+}
+
+func (r *mutatingHandler) OnDelete(client.Client, *admission.Decoder, record.EventRecorder) capsulewebhook.Func {
+	return func(ctx context.Context, req admission.Request) admission.Response {
+		return admission.Allowed("")
+	}
+}
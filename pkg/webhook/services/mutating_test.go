@@ -0,0 +1,233 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fakeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/clastix/capsule/api/v1alpha1"
+)
+
+const testNamespace = "tenant-ns"
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error adding corev1 to scheme: %v", err)
+	}
+	if err := v1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatalf("unexpected error adding v1alpha1 to scheme: %v", err)
+	}
+
+	return scheme
+}
+
+func newFakeClient(t *testing.T, tnt *v1alpha1.Tenant) client.Client {
+	t.Helper()
+
+	return fakeclient.NewClientBuilder().
+		WithScheme(newTestScheme(t)).
+		WithObjects(tnt).
+		WithIndex(&v1alpha1.Tenant{}, ".status.namespaces", func(object client.Object) []string {
+			return object.(*v1alpha1.Tenant).Status.Namespaces
+		}).
+		Build()
+}
+
+func TestApplyServiceDefaults_DefaultsLoadBalancerSourceRangesFromAllowedLoadBalancerIPs(t *testing.T) {
+	tnt := &v1alpha1.Tenant{
+		Spec: v1alpha1.TenantSpec{
+			ExternalServiceIPs: &v1alpha1.ExternalServiceIPsSpec{
+				Allowed:                []v1alpha1.CIDR{"192.168.1.0/24"},
+				AllowedLoadBalancerIPs: []v1alpha1.CIDR{"10.0.0.0/24"},
+			},
+		},
+	}
+
+	svc := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Type:        corev1.ServiceTypeLoadBalancer,
+			ExternalIPs: []string{"192.168.1.5", "8.8.8.8"},
+		},
+	}
+
+	mutated := applyServiceDefaults(tnt, svc)
+
+	if len(mutated.Spec.LoadBalancerSourceRanges) != 1 || mutated.Spec.LoadBalancerSourceRanges[0] != "10.0.0.0/24" {
+		t.Errorf("expected loadBalancerSourceRanges to default from AllowedLoadBalancerIPs, got %v", mutated.Spec.LoadBalancerSourceRanges)
+	}
+
+	if len(mutated.Spec.ExternalIPs) != 1 || mutated.Spec.ExternalIPs[0] != "192.168.1.5" {
+		t.Errorf("expected disallowed externalIPs to be stripped, got %v", mutated.Spec.ExternalIPs)
+	}
+}
+
+func TestApplyServiceDefaults_FallsBackToAllowedWhenNoLoadBalancerIPsConfigured(t *testing.T) {
+	tnt := &v1alpha1.Tenant{
+		Spec: v1alpha1.TenantSpec{
+			ExternalServiceIPs: &v1alpha1.ExternalServiceIPsSpec{
+				Allowed: []v1alpha1.CIDR{"192.168.1.0/24"},
+			},
+		},
+	}
+
+	svc := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeLoadBalancer,
+		},
+	}
+
+	mutated := applyServiceDefaults(tnt, svc)
+
+	if len(mutated.Spec.LoadBalancerSourceRanges) != 1 || mutated.Spec.LoadBalancerSourceRanges[0] != "192.168.1.0/24" {
+		t.Errorf("expected loadBalancerSourceRanges to default from ExternalServiceIPs.Allowed, got %v", mutated.Spec.LoadBalancerSourceRanges)
+	}
+}
+
+func TestApplyServiceDefaults_DoesNotOverrideExistingSourceRanges(t *testing.T) {
+	tnt := &v1alpha1.Tenant{
+		Spec: v1alpha1.TenantSpec{
+			ExternalServiceIPs: &v1alpha1.ExternalServiceIPsSpec{
+				AllowedLoadBalancerIPs: []v1alpha1.CIDR{"10.0.0.0/24"},
+			},
+		},
+	}
+
+	svc := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Type:                     corev1.ServiceTypeLoadBalancer,
+			LoadBalancerSourceRanges: []string{"172.16.0.0/24"},
+		},
+	}
+
+	mutated := applyServiceDefaults(tnt, svc)
+
+	if len(mutated.Spec.LoadBalancerSourceRanges) != 1 || mutated.Spec.LoadBalancerSourceRanges[0] != "172.16.0.0/24" {
+		t.Errorf("expected the already-set loadBalancerSourceRanges to be left untouched, got %v", mutated.Spec.LoadBalancerSourceRanges)
+	}
+}
+
+func TestApplyServiceDefaults_NonLoadBalancerServiceIsNotDefaulted(t *testing.T) {
+	tnt := &v1alpha1.Tenant{
+		Spec: v1alpha1.TenantSpec{
+			ExternalServiceIPs: &v1alpha1.ExternalServiceIPsSpec{
+				AllowedLoadBalancerIPs: []v1alpha1.CIDR{"10.0.0.0/24"},
+			},
+		},
+	}
+
+	svc := &corev1.Service{
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+		},
+	}
+
+	mutated := applyServiceDefaults(tnt, svc)
+
+	if len(mutated.Spec.LoadBalancerSourceRanges) != 0 {
+		t.Errorf("expected no loadBalancerSourceRanges defaulting for a non-LoadBalancer Service, got %v", mutated.Spec.LoadBalancerSourceRanges)
+	}
+}
+
+func TestHandleServiceDefaults_NotMutatedWhenModeIsEnforce(t *testing.T) {
+	tnt := &v1alpha1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{Name: "tnt-enforce"},
+		Spec: v1alpha1.TenantSpec{
+			ExternalServiceIPs: &v1alpha1.ExternalServiceIPsSpec{
+				AllowedLoadBalancerIPs: []v1alpha1.CIDR{"10.0.0.0/24"},
+			},
+		},
+		Status: v1alpha1.TenantStatus{Namespaces: []string{testNamespace}},
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: testNamespace},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeLoadBalancer,
+		},
+	}
+
+	clt := newFakeClient(t, tnt)
+	decoder := admission.NewDecoder(newTestScheme(t))
+
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Namespace: svc.Namespace,
+		Name:      svc.Name,
+		Object:    runtime.RawExtension{Raw: mustMarshal(t, svc)},
+	}}
+
+	h := &mutatingHandler{}
+	resp := h.handleServiceDefaults(context.Background(), clt, decoder, req)
+
+	if !resp.Allowed {
+		t.Fatalf("expected allowed response, got denied: %v", resp.Result)
+	}
+	if len(resp.Patches) != 0 {
+		t.Errorf("expected no patches when Tenant has not opted into mutate mode, got %v", resp.Patches)
+	}
+}
+
+func TestHandleServiceDefaults_PatchesWhenModeIsMutate(t *testing.T) {
+	tnt := &v1alpha1.Tenant{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "tnt-mutate",
+			Annotations: map[string]string{v1alpha1.ServiceDefaultsAnnotation: v1alpha1.ServiceDefaultsMutate},
+		},
+		Spec: v1alpha1.TenantSpec{
+			ExternalServiceIPs: &v1alpha1.ExternalServiceIPsSpec{
+				AllowedLoadBalancerIPs: []v1alpha1.CIDR{"10.0.0.0/24"},
+			},
+		},
+		Status: v1alpha1.TenantStatus{Namespaces: []string{testNamespace}},
+	}
+
+	svc := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: testNamespace},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeLoadBalancer,
+		},
+	}
+
+	clt := newFakeClient(t, tnt)
+	decoder := admission.NewDecoder(newTestScheme(t))
+
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		Namespace: svc.Namespace,
+		Name:      svc.Name,
+		Object:    runtime.RawExtension{Raw: mustMarshal(t, svc)},
+	}}
+
+	h := &mutatingHandler{}
+	resp := h.handleServiceDefaults(context.Background(), clt, decoder, req)
+
+	if !resp.Allowed {
+		t.Fatalf("expected allowed response, got denied: %v", resp.Result)
+	}
+	if len(resp.Patches) == 0 {
+		t.Errorf("expected a patch defaulting loadBalancerSourceRanges when Tenant opted into mutate mode")
+	}
+}
+
+func mustMarshal(t *testing.T, svc *corev1.Service) []byte {
+	t.Helper()
+
+	raw, err := json.Marshal(svc)
+	if err != nil {
+		t.Fatalf("unexpected error marshaling Service: %v", err)
+	}
+
+	return raw
+}
@@ -21,10 +21,6 @@ import (
 
 // +kubebuilder:webhook:path=/validating-external-service-ips,mutating=false,sideEffects=None,admissionReviewVersions=v1,failurePolicy=fail,groups="",resources=services,verbs=create;update,versions=v1,name=validating-external-service-ips.capsule.clastix.io
 
-const (
-	enableNodePortsAnnotation = "capsule.clastix.io/enable-node-ports"
-)
-
 type webhook struct {
 	handler capsulewebhook.Handler
 }
@@ -68,41 +64,72 @@ func (r *handler) handleService(ctx context.Context, clt client.Client, decoder
 	}
 	tnt := tntList.Items[0]
 
-	if svc.Spec.Type == corev1.ServiceTypeNodePort && tnt.GetAnnotations()[enableNodePortsAnnotation] == "false" {
-		recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenNodePort", "Service %s/%s cannot be type of NodePort for the current Tenant", req.Namespace, req.Name)
-
-		return admission.Errored(http.StatusBadRequest, NewNodePortDisabledError())
-	}
+	if options := tnt.ServiceOptions(); options != nil && len(options.AllowedTypes) > 0 && !serviceTypeAllowed(options.AllowedTypes, svc.Spec.Type) {
+		recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenServiceType", "Service %s/%s cannot be of type %s for the current Tenant", req.Namespace, req.Name, svc.Spec.Type)
 
-	if svc.Spec.ExternalIPs == nil || tnt.Spec.ExternalServiceIPs == nil {
-		return admission.Allowed("")
+		return admission.Errored(http.StatusBadRequest, NewServiceTypeForbiddenError(options.AllowedTypes))
 	}
 
-	ipInCIDR := func(ip net.IP) bool {
-		for _, allowed := range tnt.Spec.ExternalServiceIPs.Allowed {
-			if !strings.Contains(string(allowed), "/") {
-				allowed += "/32"
-			}
-
-			_, allowedIP, _ := net.ParseCIDR(string(allowed))
+	if svc.Spec.Type == corev1.ServiceTypeExternalName && tnt.Spec.ExternalName != nil {
+		if !hostnameAllowed(tnt.Spec.ExternalName.AllowedHostnames, svc.Spec.ExternalName) {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenExternalName", "Service %s/%s externalName %s is forbidden for the current Tenant", req.Namespace, req.Name, svc.Spec.ExternalName)
 
-			if allowedIP.Contains(ip) {
-				return true
-			}
+			return admission.Errored(http.StatusBadRequest, NewExternalNameForbidden(tnt.Spec.ExternalName.AllowedHostnames))
 		}
-		return false
+	}
+
+	// The externalIPs/loadBalancer* checks below are the ones a Tenant can opt out of hard
+	// rejection for. In mutate mode the mutating webhook only defaults empty
+	// loadBalancerSourceRanges and strips disallowed externalIPs; the other checks are skipped.
+	if tnt.Spec.ExternalServiceIPs == nil || tnt.ServiceDefaultsMode() != v1alpha1.ServiceDefaultsEnforce {
+		return admission.Allowed("")
 	}
 
 	for _, externalIP := range svc.Spec.ExternalIPs {
 		ip := net.ParseIP(externalIP)
 
-		if !ipInCIDR(ip) {
+		if !cidrListContainsIP(tnt.Spec.ExternalServiceIPs.Allowed, ip) {
 			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenExternalServiceIP", "Service %s/%s external IP %s is forbidden for the current Tenant", req.Namespace, req.Name, ip.String())
 
 			return admission.Errored(http.StatusBadRequest, NewExternalServiceIPForbidden(tnt.Spec.ExternalServiceIPs.Allowed))
 		}
 	}
 
+	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
+		return admission.Allowed("")
+	}
+
+	if svc.Spec.LoadBalancerIP != "" && len(tnt.Spec.ExternalServiceIPs.AllowedLoadBalancerIPs) > 0 {
+		ip := net.ParseIP(svc.Spec.LoadBalancerIP)
+
+		if !cidrListContainsIP(tnt.Spec.ExternalServiceIPs.AllowedLoadBalancerIPs, ip) {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "ForbiddenLoadBalancerIP", "Service %s/%s load balancer IP %s is forbidden for the current Tenant", req.Namespace, req.Name, ip.String())
+
+			return admission.Errored(http.StatusBadRequest, NewLoadBalancerIPForbiddenError(tnt.Spec.ExternalServiceIPs.AllowedLoadBalancerIPs))
+		}
+	}
+
+	if required := tnt.Spec.ExternalServiceIPs.RequiredLoadBalancerSourceRanges; len(required) > 0 {
+		if len(svc.Spec.LoadBalancerSourceRanges) == 0 {
+			recorder.Eventf(&tnt, corev1.EventTypeWarning, "MissingLoadBalancerSourceRanges", "Service %s/%s must declare loadBalancerSourceRanges for the current Tenant", req.Namespace, req.Name)
+
+			return admission.Errored(http.StatusBadRequest, NewMissingLoadBalancerSourceRangesError(required))
+		}
+
+		for _, sourceRange := range svc.Spec.LoadBalancerSourceRanges {
+			_, sourceCIDR, err := net.ParseCIDR(sourceRange)
+			if err != nil {
+				return admission.Errored(http.StatusBadRequest, err)
+			}
+
+			if !cidrListContainsCIDR(required, sourceCIDR) {
+				recorder.Eventf(&tnt, corev1.EventTypeWarning, "MissingLoadBalancerSourceRanges", "Service %s/%s loadBalancerSourceRange %s is not allowed for the current Tenant", req.Namespace, req.Name, sourceRange)
+
+				return admission.Errored(http.StatusBadRequest, NewLoadBalancerSourceRangeForbiddenError(required))
+			}
+		}
+	}
+
 	return admission.Allowed("")
 }
 
@@ -123,3 +150,75 @@ func (r *handler) OnDelete(client.Client, *admission.Decoder, record.EventRecord
 		return admission.Allowed("")
 	}
 }
+
+func toIPNet(cidr v1alpha1.CIDR) *net.IPNet {
+	value := string(cidr)
+	if !strings.Contains(value, "/") {
+		value += "/32"
+	}
+
+	_, ipNet, _ := net.ParseCIDR(value)
+
+	return ipNet
+}
+
+// hostnameAllowed reports whether hostname matches one of the allowed entries, either exactly
+// or via a single-label wildcard prefix such as *.example.com.
+func hostnameAllowed(allowed []string, hostname string) bool {
+	for _, pattern := range allowed {
+		if pattern == hostname {
+			return true
+		}
+
+		if suffix := strings.TrimPrefix(pattern, "*."); suffix != pattern && strings.HasSuffix(hostname, "."+suffix) {
+			label := strings.TrimSuffix(hostname, "."+suffix)
+
+			if label != "" && !strings.Contains(label, ".") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// serviceTypeAllowed reports whether svcType is present in allowed.
+func serviceTypeAllowed(allowed []corev1.ServiceType, svcType corev1.ServiceType) bool {
+	for _, t := range allowed {
+		if t == svcType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cidrListContainsIP reports whether ip falls within any of the CIDRs in allowed.
+func cidrListContainsIP(allowed []v1alpha1.CIDR, ip net.IP) bool {
+	for _, cidr := range allowed {
+		if ipNet := toIPNet(cidr); ipNet != nil && ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// cidrListContainsCIDR reports whether candidate is fully contained within any of the CIDRs in allowed.
+func cidrListContainsCIDR(allowed []v1alpha1.CIDR, candidate *net.IPNet) bool {
+	for _, cidr := range allowed {
+		ipNet := toIPNet(cidr)
+		if ipNet == nil {
+			continue
+		}
+
+		candidateOnes, _ := candidate.Mask.Size()
+		allowedOnes, _ := ipNet.Mask.Size()
+
+		if allowedOnes <= candidateOnes && ipNet.Contains(candidate.IP) {
+			return true
+		}
+	}
+
+	return false
+}
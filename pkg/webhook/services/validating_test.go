@@ -0,0 +1,86 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package services
+
+import (
+	"net"
+	"testing"
+
+	"github.com/clastix/capsule/api/v1alpha1"
+)
+
+func TestHostnameAllowed(t *testing.T) {
+	allowed := []string{"svc.example.com", "*.example.com"}
+
+	tt := []struct {
+		name     string
+		hostname string
+		expected bool
+	}{
+		{name: "exact match", hostname: "svc.example.com", expected: true},
+		{name: "single-label wildcard match", hostname: "foo.example.com", expected: true},
+		{name: "multi-label hostname rejected by wildcard", hostname: "evil.attacker.example.com", expected: false},
+		{name: "multi-label hostname rejected even when prefixed", hostname: "a.b.example.com", expected: false},
+		{name: "unrelated domain rejected", hostname: "example.org", expected: false},
+		{name: "empty label rejected", hostname: "example.com", expected: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := hostnameAllowed(allowed, tc.hostname); got != tc.expected {
+				t.Errorf("hostnameAllowed(%v, %q) = %v, expected %v", allowed, tc.hostname, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCidrListContainsIP(t *testing.T) {
+	allowed := []v1alpha1.CIDR{"192.168.1.0/24", "10.0.0.5"}
+
+	tt := []struct {
+		name     string
+		ip       string
+		expected bool
+	}{
+		{name: "ip within CIDR range", ip: "192.168.1.42", expected: true},
+		{name: "ip matching bare /32 entry", ip: "10.0.0.5", expected: true},
+		{name: "ip outside all ranges", ip: "172.16.0.1", expected: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := cidrListContainsIP(allowed, net.ParseIP(tc.ip)); got != tc.expected {
+				t.Errorf("cidrListContainsIP(%v, %q) = %v, expected %v", allowed, tc.ip, got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCidrListContainsCIDR(t *testing.T) {
+	allowed := []v1alpha1.CIDR{"192.168.0.0/16"}
+
+	tt := []struct {
+		name      string
+		candidate string
+		expected  bool
+	}{
+		{name: "subset of allowed range", candidate: "192.168.1.0/24", expected: true},
+		{name: "exact match", candidate: "192.168.0.0/16", expected: true},
+		{name: "broader than allowed range", candidate: "192.168.0.0/8", expected: false},
+		{name: "disjoint range", candidate: "10.0.0.0/24", expected: false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			_, candidate, err := net.ParseCIDR(tc.candidate)
+			if err != nil {
+				t.Fatalf("unexpected error parsing %q: %v", tc.candidate, err)
+			}
+
+			if got := cidrListContainsCIDR(allowed, candidate); got != tc.expected {
+				t.Errorf("cidrListContainsCIDR(%v, %q) = %v, expected %v", allowed, tc.candidate, got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright 2020-2021 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// Func is the admission logic for a single operation handled by a Handler.
+type Func func(ctx context.Context, req admission.Request) admission.Response
+
+// Handler builds the admission Func for each operation a Webhook reacts to.
+type Handler interface {
+	OnCreate(client.Client, *admission.Decoder, record.EventRecorder) Func
+	OnUpdate(client.Client, *admission.Decoder, record.EventRecorder) Func
+	OnDelete(client.Client, *admission.Decoder, record.EventRecorder) Func
+}
+
+// Webhook describes an admission webhook to be registered with the manager.
+type Webhook interface {
+	GetHandler() Handler
+	GetName() string
+	GetPath() string
+}